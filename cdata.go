@@ -0,0 +1,183 @@
+package xpp
+
+import (
+	"bufio"
+	"io"
+)
+
+// cdataMode tracks which part of the raw byte stream cdataReader is
+// currently forwarding.
+type cdataMode int
+
+const (
+	modeContent cdataMode = iota // plain character data
+	modeCDATA                    // inside <![CDATA[ ... ]]>
+	modeTag                      // inside <tag ...> or <tag .../>
+	modeComment                  // inside <!-- ... -->
+	modePI                       // inside <? ... ?>
+)
+
+// cdataReader wraps an io.Reader and records, in document order, whether
+// each contiguous character-data run it passes through originated from a
+// <![CDATA[ ... ]]> section rather than plain char data. [xml.Decoder]
+// collapses both into an indistinguishable xml.CharData token, so this
+// layer peeks the raw byte stream ahead of the decoder to recover that
+// distinction, relying on the fact that in well-formed XML a literal '<'
+// outside of a CDATA section always starts markup.
+//
+// A flag is recorded for a plain char-data run once it closes with at
+// least one byte of content, and for every CDATA section, empty or not,
+// since [xml.Decoder] emits a CharData token even for an empty one. It is
+// never recorded for the bytes of a tag, comment or processing
+// instruction, so the flags line up 1:1 with the CharData tokens
+// [xml.Decoder] actually emits. Quoted attribute values
+// may contain '>' without ending the tag; a DOCTYPE's bracketed internal
+// subset is not specially handled, matching this package's existing
+// Directive support.
+//
+// Bytes are passed through unmodified; only the classification is
+// recorded. If several runs collapse into a single token (e.g. the
+// decoder stitches adjacent char data together), the token is reported
+// using the first run's classification.
+type cdataReader struct {
+	src   *bufio.Reader
+	flags []bool
+
+	mode   cdataMode
+	quote  byte // active quote byte while mode == modeTag, 0 if none
+	runLen int
+}
+
+func newCDATAReader(r io.Reader) *cdataReader {
+	return &cdataReader{src: bufio.NewReader(r)}
+}
+
+func (r *cdataReader) endRun(isCDATA bool) {
+	if r.runLen > 0 {
+		r.flags = append(r.flags, isCDATA)
+		r.runLen = 0
+	}
+}
+
+// next pops the classification of the next completed content run, or
+// false if none is available yet.
+func (r *cdataReader) next() bool {
+	if len(r.flags) == 0 {
+		return false
+	}
+	v := r.flags[0]
+	r.flags = r.flags[1:]
+	return v
+}
+
+// forward writes b to p, then consumes and forwards the n bytes already
+// confirmed present via Peek, stopping early (without losing any bytes)
+// if p runs out of room.
+func (r *cdataReader) forward(p []byte, pos int, b byte, n int) int {
+	p[pos] = b
+	pos++
+	for i := 0; i < n && pos < len(p); i++ {
+		c, _ := r.src.ReadByte()
+		p[pos] = c
+		pos++
+	}
+	return pos
+}
+
+func (r *cdataReader) Read(p []byte) (int, error) {
+	n := 0
+	for n < len(p) {
+		// Stop short rather than split a markup opener or closer (at most
+		// 9 bytes, "<![CDATA[") across two reads, which would leave its
+		// tail to be classified as content.
+		if n > 0 && len(p)-n < 9 {
+			break
+		}
+		b, err := r.src.ReadByte()
+		if err != nil {
+			if n > 0 {
+				return n, nil
+			}
+			return n, err
+		}
+
+		switch r.mode {
+		case modeContent:
+			if b != '<' {
+				r.runLen++
+				p[n] = b
+				n++
+				continue
+			}
+			r.endRun(false)
+			switch peeked, _ := r.src.Peek(8); {
+			case string(peeked) == "![CDATA[":
+				n = r.forward(p, n, b, 8)
+				r.mode = modeCDATA
+			case len(peeked) >= 3 && string(peeked[:3]) == "!--":
+				n = r.forward(p, n, b, 3)
+				r.mode = modeComment
+			case len(peeked) >= 1 && peeked[0] == '?':
+				p[n] = b
+				n++
+				r.mode = modePI
+			default:
+				p[n] = b
+				n++
+				r.mode = modeTag
+				r.quote = 0
+			}
+
+		case modeCDATA:
+			if b == ']' {
+				if peeked, perr := r.src.Peek(2); perr == nil && string(peeked) == "]>" {
+					n = r.forward(p, n, b, 2)
+					r.flags = append(r.flags, true)
+					r.runLen = 0
+					r.mode = modeContent
+					continue
+				}
+			}
+			r.runLen++
+			p[n] = b
+			n++
+
+		case modeTag:
+			switch {
+			case r.quote != 0:
+				if b == r.quote {
+					r.quote = 0
+				}
+			case b == '"' || b == '\'':
+				r.quote = b
+			case b == '>':
+				r.mode = modeContent
+			}
+			p[n] = b
+			n++
+
+		case modeComment:
+			if b == '-' {
+				if peeked, perr := r.src.Peek(2); perr == nil && string(peeked) == "->" {
+					n = r.forward(p, n, b, 2)
+					r.mode = modeContent
+					continue
+				}
+			}
+			p[n] = b
+			n++
+
+		case modePI:
+			if b == '?' {
+				if peeked, perr := r.src.Peek(1); perr == nil && peeked[0] == '>' {
+					n = r.forward(p, n, b, 1)
+					r.mode = modeContent
+					continue
+				}
+			}
+			p[n] = b
+			n++
+		}
+	}
+	return n, nil
+}
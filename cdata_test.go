@@ -0,0 +1,90 @@
+package xpp
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestXMLPullParser_PreserveCDATA(t *testing.T) {
+	const doc = `<root>plain <![CDATA[a<b>&c]]> text<child/></root>`
+
+	p := NewXMLPullParser(strings.NewReader(doc), false, nil, WithPreserveCDATA(true))
+
+	type tok struct {
+		event XMLEventType
+		text  string
+		cdata bool
+	}
+	var got []tok
+	for {
+		event, err := p.NextToken()
+		require.NoError(t, err)
+		got = append(got, tok{event, p.Text(), p.IsCDATA()})
+		if event == EndDocument {
+			break
+		}
+	}
+
+	want := []tok{
+		{StartTag, "", false},
+		{Text, "plain ", false},
+		{CDSECT, "a<b>&c", true},
+		{Text, " text", false},
+		{StartTag, "", false},
+		{EndTag, "", false},
+		{EndTag, "", false},
+		{EndDocument, "", false},
+	}
+	assert.Equal(t, want, got)
+}
+
+// TestXMLPullParser_PreserveCDATA_AfterSiblingElement guards against the
+// classification queue desyncing when a tag with no intervening char data
+// (here a self-closing sibling) sits directly before a CDATA section.
+func TestXMLPullParser_PreserveCDATA_AfterSiblingElement(t *testing.T) {
+	const doc = `<a>1<b/><![CDATA[2]]></a>`
+
+	p := NewXMLPullParser(strings.NewReader(doc), false, nil, WithPreserveCDATA(true))
+
+	type tok struct {
+		event XMLEventType
+		text  string
+		cdata bool
+	}
+	var got []tok
+	for {
+		event, err := p.NextToken()
+		require.NoError(t, err)
+		got = append(got, tok{event, p.Text(), p.IsCDATA()})
+		if event == EndDocument {
+			break
+		}
+	}
+
+	want := []tok{
+		{StartTag, "", false},
+		{Text, "1", false},
+		{StartTag, "", false},
+		{EndTag, "", false},
+		{CDSECT, "2", true},
+		{EndTag, "", false},
+		{EndDocument, "", false},
+	}
+	assert.Equal(t, want, got)
+}
+
+func TestXMLPullParser_PreserveCDATA_Disabled(t *testing.T) {
+	const doc = `<root><![CDATA[hi]]></root>`
+
+	p := NewXMLPullParser(strings.NewReader(doc), false, nil)
+
+	p.NextTag()
+	event, err := p.Next()
+	require.NoError(t, err)
+	assert.Equal(t, Text, event)
+	assert.Equal(t, "hi", p.Text())
+	assert.False(t, p.IsCDATA())
+}
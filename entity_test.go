@@ -0,0 +1,48 @@
+package xpp
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestWithEntities(t *testing.T) {
+	p := NewXMLPullParser(strings.NewReader("<root>&nbsp;</root>"), false, nil,
+		WithEntities(map[string]string{"nbsp": " "}))
+
+	p.NextTag()
+	_, err := p.Next()
+	require.NoError(t, err)
+	assert.Equal(t, " ", p.Text())
+}
+
+type mapResolver map[string]string
+
+func (r mapResolver) ResolveEntity(name string) (string, error) {
+	if v, ok := r[name]; ok {
+		return v, nil
+	}
+	return "", errors.New("unknown entity")
+}
+
+func (r mapResolver) EntityNames() []string {
+	names := make([]string, 0, len(r))
+	for name := range r {
+		names = append(names, name)
+	}
+	return names
+}
+
+func TestWithEntityResolver(t *testing.T) {
+	resolver := mapResolver{"copy": "©"}
+	p := NewXMLPullParser(strings.NewReader("<root>&copy;</root>"), false, nil,
+		WithEntityResolver(resolver))
+
+	p.NextTag()
+	_, err := p.Next()
+	require.NoError(t, err)
+	assert.Equal(t, "©", p.Text())
+}
@@ -0,0 +1,107 @@
+// Package htmlentities provides a ready-made [xpp.EntityResolver] for a
+// subset of the named character references defined by HTML (those listed
+// in [Entities]), for use with
+// [xpp.WithEntityResolver] or [xpp.WithEntities] when parsing Atom/RSS
+// feeds or XHTML fragments that reference entities such as "&nbsp;" that
+// Go's stock [encoding/xml] decoder rejects.
+package htmlentities
+
+import "fmt"
+
+// Entities maps each HTML named character reference (without its
+// surrounding '&' and ';') to its replacement text. It covers the
+// entities defined by HTML 4 / Latin-1 and the common symbol and Greek
+// letter references carried forward into HTML5. Names defined only by
+// HTML5 are not included, nor are some less common HTML 4 ones such as
+// "thetasym", "weierp", "alefsym", "lArr" or "lang".
+var Entities = map[string]string{
+	"quot": "\"", "amp": "&", "apos": "'", "lt": "<", "gt": ">",
+
+	"nbsp": " ", "iexcl": "¡", "cent": "¢", "pound": "£",
+	"curren": "¤", "yen": "¥", "brvbar": "¦", "sect": "§",
+	"uml": "¨", "copy": "©", "ordf": "ª", "laquo": "«",
+	"not": "¬", "shy": "­", "reg": "®", "macr": "¯",
+	"deg": "°", "plusmn": "±", "sup2": "²", "sup3": "³",
+	"acute": "´", "micro": "µ", "para": "¶", "middot": "·",
+	"cedil": "¸", "sup1": "¹", "ordm": "º", "raquo": "»",
+	"frac14": "¼", "frac12": "½", "frac34": "¾", "iquest": "¿",
+
+	"Agrave": "À", "Aacute": "Á", "Acirc": "Â", "Atilde": "Ã",
+	"Auml": "Ä", "Aring": "Å", "AElig": "Æ", "Ccedil": "Ç",
+	"Egrave": "È", "Eacute": "É", "Ecirc": "Ê", "Euml": "Ë",
+	"Igrave": "Ì", "Iacute": "Í", "Icirc": "Î", "Iuml": "Ï",
+	"ETH": "Ð", "Ntilde": "Ñ", "Ograve": "Ò", "Oacute": "Ó",
+	"Ocirc": "Ô", "Otilde": "Õ", "Ouml": "Ö", "times": "×",
+	"Oslash": "Ø", "Ugrave": "Ù", "Uacute": "Ú", "Ucirc": "Û",
+	"Uuml": "Ü", "Yacute": "Ý", "THORN": "Þ", "szlig": "ß",
+
+	"agrave": "à", "aacute": "á", "acirc": "â", "atilde": "ã",
+	"auml": "ä", "aring": "å", "aelig": "æ", "ccedil": "ç",
+	"egrave": "è", "eacute": "é", "ecirc": "ê", "euml": "ë",
+	"igrave": "ì", "iacute": "í", "icirc": "î", "iuml": "ï",
+	"eth": "ð", "ntilde": "ñ", "ograve": "ò", "oacute": "ó",
+	"ocirc": "ô", "otilde": "õ", "ouml": "ö", "divide": "÷",
+	"oslash": "ø", "ugrave": "ù", "uacute": "ú", "ucirc": "û",
+	"uuml": "ü", "yacute": "ý", "thorn": "þ", "yuml": "ÿ",
+
+	"OElig": "Œ", "oelig": "œ", "Scaron": "Š", "scaron": "š",
+	"Yuml": "Ÿ", "fnof": "ƒ", "circ": "ˆ", "tilde": "˜",
+
+	"Alpha": "Α", "Beta": "Β", "Gamma": "Γ", "Delta": "Δ",
+	"Epsilon": "Ε", "Zeta": "Ζ", "Eta": "Η", "Theta": "Θ",
+	"Iota": "Ι", "Kappa": "Κ", "Lambda": "Λ", "Mu": "Μ",
+	"Nu": "Ν", "Xi": "Ξ", "Omicron": "Ο", "Pi": "Π",
+	"Rho": "Ρ", "Sigma": "Σ", "Tau": "Τ", "Upsilon": "Υ",
+	"Phi": "Φ", "Chi": "Χ", "Psi": "Ψ", "Omega": "Ω",
+	"alpha": "α", "beta": "β", "gamma": "γ", "delta": "δ",
+	"epsilon": "ε", "zeta": "ζ", "eta": "η", "theta": "θ",
+	"iota": "ι", "kappa": "κ", "lambda": "λ", "mu": "μ",
+	"nu": "ν", "xi": "ξ", "omicron": "ο", "pi": "π",
+	"rho": "ρ", "sigmaf": "ς", "sigma": "σ", "tau": "τ",
+	"upsilon": "υ", "phi": "φ", "chi": "χ", "psi": "ψ",
+	"omega": "ω",
+
+	"ensp": " ", "emsp": " ", "thinsp": " ", "zwnj": "‌",
+	"zwj": "‍", "lrm": "‎", "rlm": "‏", "ndash": "–",
+	"mdash": "—", "lsquo": "‘", "rsquo": "’", "sbquo": "‚",
+	"ldquo": "“", "rdquo": "”", "bdquo": "„", "dagger": "†",
+	"Dagger": "‡", "bull": "•", "hellip": "…", "permil": "‰",
+	"prime": "′", "Prime": "″", "lsaquo": "‹", "rsaquo": "›",
+	"oline": "‾", "frasl": "⁄", "euro": "€",
+
+	"trade": "™", "larr": "←", "uarr": "↑", "rarr": "→",
+	"darr": "↓", "harr": "↔", "crarr": "↵", "forall": "∀",
+	"part": "∂", "exist": "∃", "empty": "∅", "nabla": "∇",
+	"isin": "∈", "notin": "∉", "ni": "∋", "prod": "∏",
+	"sum": "∑", "minus": "−", "lowast": "∗", "radic": "√",
+	"prop": "∝", "infin": "∞", "ang": "∠", "and": "∧",
+	"or": "∨", "cap": "∩", "cup": "∪", "int": "∫",
+	"there4": "∴", "sim": "∼", "cong": "≅", "asymp": "≈",
+	"ne": "≠", "equiv": "≡", "le": "≤", "ge": "≥",
+	"sub": "⊂", "sup": "⊃", "nsub": "⊄", "sube": "⊆",
+	"supe": "⊇", "oplus": "⊕", "otimes": "⊗", "perp": "⊥",
+	"sdot": "⋅", "lceil": "⌈", "rceil": "⌉", "lfloor": "⌊",
+	"rfloor": "⌋", "loz": "◊", "spades": "♠", "clubs": "♣",
+	"hearts": "♥", "diams": "♦",
+}
+
+// Resolver adapts [Entities] to the xpp.EntityResolver interface.
+type Resolver struct{}
+
+// ResolveEntity resolves name against [Entities].
+func (Resolver) ResolveEntity(name string) (string, error) {
+	if value, ok := Entities[name]; ok {
+		return value, nil
+	}
+	return "", fmt.Errorf("htmlentities: unknown entity %q", name)
+}
+
+// EntityNames returns the name of every entity in [Entities], letting
+// xpp.WithEntityResolver eagerly populate its static entity map.
+func (Resolver) EntityNames() []string {
+	names := make([]string, 0, len(Entities))
+	for name := range Entities {
+		names = append(names, name)
+	}
+	return names
+}
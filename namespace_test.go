@@ -0,0 +1,67 @@
+package xpp
+
+import (
+	"encoding/xml"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestXMLPullParser_AttributeNS(t *testing.T) {
+	const doc = `<feed xmlns="http://www.w3.org/2005/Atom" xmlns:a="urn:a">` +
+		`<entry a:id="1" id="2"/></feed>`
+
+	p := NewXMLPullParser(strings.NewReader(doc), false, nil)
+	_, err := p.NextTag()
+	require.NoError(t, err)
+	_, err = p.NextTag()
+	require.NoError(t, err)
+
+	assert.Equal(t, "1", p.AttributeNS("urn:a", "id"))
+	assert.Equal(t, "2", p.AttributeNS("", "id"))
+	assert.Equal(t, "", p.AttributeNS("urn:other", "id"))
+}
+
+func TestXMLPullParser_XMLName(t *testing.T) {
+	const doc = `<a:feed xmlns:a="http://www.w3.org/2005/Atom"/>`
+
+	p := NewXMLPullParser(strings.NewReader(doc), false, nil)
+	_, err := p.NextTag()
+	require.NoError(t, err)
+	assert.Equal(t,
+		xml.Name{Space: "http://www.w3.org/2005/Atom", Local: "feed"},
+		p.XMLName())
+
+	_, err = p.NextTag()
+	require.NoError(t, err)
+	assert.Equal(t,
+		xml.Name{Space: "http://www.w3.org/2005/Atom", Local: "feed"},
+		p.XMLName())
+}
+
+func TestXMLPullParser_XMLName_NotOnTag(t *testing.T) {
+	p := NewXMLPullParser(strings.NewReader(`<a>text</a>`), false, nil)
+	_, err := p.NextTag()
+	require.NoError(t, err)
+	_, err = p.Next()
+	require.NoError(t, err)
+	assert.Equal(t, xml.Name{}, p.XMLName())
+}
+
+// TestXMLPullParser_ExpectAll_NamespaceURI confirms ExpectAll already
+// matches space against the token's canonical namespace URI, since
+// [xml.Decoder] resolves p.Space to that URI rather than the document's
+// (arbitrary) prefix.
+func TestXMLPullParser_ExpectAll_NamespaceURI(t *testing.T) {
+	const doc = `<atom:feed xmlns:atom="http://www.w3.org/2005/Atom"/>`
+
+	p := NewXMLPullParser(strings.NewReader(doc), false, nil)
+	_, err := p.NextTag()
+	require.NoError(t, err)
+
+	require.NoError(t,
+		p.ExpectAll(StartTag, "http://www.w3.org/2005/Atom", "feed"))
+	assert.Error(t, p.ExpectAll(StartTag, "urn:other", "feed"))
+}
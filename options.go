@@ -1,6 +1,9 @@
 package xpp
 
-import "encoding/xml"
+import (
+	"encoding/xml"
+	"maps"
+)
 
 type Option func(p *XMLPullParser)
 
@@ -11,3 +14,56 @@ type Option func(p *XMLPullParser)
 func WithDecoder(d *xml.Decoder) Option {
 	return func(p *XMLPullParser) { p.decoder = d }
 }
+
+// WithPreserveCDATA makes the parser distinguish text originating from a
+// <![CDATA[ ... ]]> section from ordinary character data. When enabled,
+// [XMLPullParser.NextToken] reports such text as a CDSECT event instead of
+// Text, and [XMLPullParser.IsCDATA] reports whether the current token came
+// from a CDATA section.
+//
+// It has no effect when combined with [WithDecoder], since CDATA detection
+// requires wrapping the raw input reader before it reaches [xml.Decoder].
+func WithPreserveCDATA(preserve bool) Option {
+	return func(p *XMLPullParser) { p.preserveCDATA = preserve }
+}
+
+// EntityResolver resolves named XML/HTML entity references (without their
+// surrounding '&' and ';') to their replacement text.
+//
+// [xml.Decoder] only accepts a static entity map, with no hook to resolve
+// entities on demand as they're encountered mid-stream, so
+// [WithEntityResolver] consults EntityNames to eagerly resolve and
+// register every entity the resolver knows about up front.
+type EntityResolver interface {
+	ResolveEntity(name string) (string, error)
+	EntityNames() []string
+}
+
+// WithEntities registers additional named entities the parser should
+// accept, merging them into the underlying [xml.Decoder]'s Entity map.
+// Use this to accept entities such as HTML named character references
+// ("&nbsp;") that Go's stock decoder otherwise rejects.
+func WithEntities(entities map[string]string) Option {
+	return func(p *XMLPullParser) {
+		if p.entities == nil {
+			p.entities = make(map[string]string, len(entities))
+		}
+		maps.Copy(p.entities, entities)
+	}
+}
+
+// WithEntityResolver registers every entity r reports via EntityNames,
+// resolved through r.ResolveEntity, with the parser.
+func WithEntityResolver(r EntityResolver) Option {
+	return func(p *XMLPullParser) {
+		names := r.EntityNames()
+		if p.entities == nil {
+			p.entities = make(map[string]string, len(names))
+		}
+		for _, name := range names {
+			if value, err := r.ResolveEntity(name); err == nil {
+				p.entities[name] = value
+			}
+		}
+	}
+}
@@ -0,0 +1,90 @@
+package xpp
+
+import (
+	"fmt"
+	"io"
+)
+
+// PositionError wraps an error produced while parsing with the document
+// position and token state the parser had reached when it occurred,
+// playing the same role as [encoding/xml.SyntaxError] but carrying the
+// richer positional and token state [XMLPullParser] already tracks.
+type PositionError struct {
+	Line, Column int
+	Offset       int64
+	Event        XMLEventType
+	Name, Space  string
+	Err          error
+}
+
+func (e *PositionError) Error() string {
+	return fmt.Sprintf("goxpp: %s (line %d, column %d, offset %d)",
+		e.Err, e.Line, e.Column, e.Offset)
+}
+
+func (e *PositionError) Unwrap() error { return e.Err }
+
+// wrapErr wraps err, together with the parser's current position and
+// token state, in a *PositionError.
+func (p *XMLPullParser) wrapErr(err error) error {
+	return &PositionError{
+		Line:   p.Line(),
+		Column: p.Column(),
+		Offset: p.Offset(),
+		Event:  p.Event,
+		Name:   p.Name,
+		Space:  p.Space,
+		Err:    err,
+	}
+}
+
+// Line returns the current 1-based line number the parser has read up to.
+// It is approximate: [encoding/xml.Decoder] buffers input internally, so
+// the reported position can be ahead of the token currently being
+// processed. It returns 0 if the parser was constructed with
+// [WithDecoder], since line tracking requires wrapping the raw input
+// reader before it reaches [xml.Decoder].
+func (p *XMLPullParser) Line() int {
+	if p.lineCounter == nil {
+		return 0
+	}
+	return p.lineCounter.line
+}
+
+// Column returns the current 1-based column number on the line reported
+// by [XMLPullParser.Line], subject to the same caveats.
+func (p *XMLPullParser) Column() int {
+	if p.lineCounter == nil {
+		return 0
+	}
+	return p.lineCounter.column
+}
+
+// Offset returns the number of input bytes consumed so far, equivalent to
+// [xml.Decoder.InputOffset].
+func (p *XMLPullParser) Offset() int64 { return p.decoder.InputOffset() }
+
+// lineCounter wraps an io.Reader, tracking the line and column reached by
+// the farthest byte read through it.
+type lineCounter struct {
+	src    io.Reader
+	line   int
+	column int
+}
+
+func newLineCounter(r io.Reader) *lineCounter {
+	return &lineCounter{src: r, line: 1, column: 1}
+}
+
+func (c *lineCounter) Read(p []byte) (int, error) {
+	n, err := c.src.Read(p)
+	for _, b := range p[:n] {
+		if b == '\n' {
+			c.line++
+			c.column = 1
+		} else {
+			c.column++
+		}
+	}
+	return n, err
+}
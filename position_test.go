@@ -0,0 +1,55 @@
+package xpp
+
+import (
+	"encoding/xml"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestXMLPullParser_LineColumn(t *testing.T) {
+	p := NewXMLPullParser(strings.NewReader("<root>\n  <child/>\n</root>"), false, nil)
+
+	_, err := p.NextTag() // root
+	require.NoError(t, err)
+	_, err = p.NextTag() // child
+	require.NoError(t, err)
+
+	// Line() tracks bytes seen by the underlying reader, which may run
+	// ahead of decoder.Token() due to internal buffering, so assert a
+	// lower bound rather than an exact line.
+	assert.GreaterOrEqual(t, p.Line(), 2)
+	assert.Positive(t, p.Column())
+	assert.Positive(t, p.Offset())
+}
+
+func TestXMLPullParser_LineColumn_WithDecoder(t *testing.T) {
+	p := NewXMLPullParser(nil, false, nil,
+		WithDecoder(xml.NewDecoder(strings.NewReader("<root/>"))))
+
+	_, err := p.NextTag()
+	require.NoError(t, err)
+	assert.Equal(t, 0, p.Line())
+	assert.Equal(t, 0, p.Column())
+}
+
+func TestPositionError(t *testing.T) {
+	p := NewXMLPullParser(strings.NewReader("<root>\n  <bad\n"), false, nil)
+
+	var err error
+	for {
+		_, err = p.NextToken()
+		if err != nil {
+			break
+		}
+	}
+
+	var posErr *PositionError
+	require.True(t, errors.As(err, &posErr))
+	assert.Equal(t, 3, posErr.Line)
+	assert.Contains(t, posErr.Error(), "line 3")
+	assert.Error(t, posErr.Unwrap())
+}
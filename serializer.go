@@ -0,0 +1,353 @@
+package xpp
+
+import (
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"io"
+	"net/url"
+)
+
+// elementScope tracks the state pushed onto the serializer's stack by a
+// single StartTag, so the matching EndTag can emit the right closing tag
+// and undo any namespace/xml:base bindings it introduced.
+type elementScope struct {
+	name    xml.Name
+	uris    map[string]string // namespace URI -> bound prefix, declared on this element
+	attrNS  map[string]string // namespace URI -> prefix synthesized for an attribute on this element
+	hasBase bool
+}
+
+// xmlPrefix is the reserved prefix for [xmlNSURI]. Unlike every other
+// prefix it is implicitly bound in every scope and never needs an xmlns
+// declaration.
+const xmlPrefix = "xml"
+
+// XMLPullSerializer writes an XML document one token at a time, mirroring
+// the pull-parsing API of [XMLPullParser] for output. It writes directly
+// to the underlying [io.Writer] without building a DOM.
+type XMLPullSerializer struct {
+	w io.Writer
+
+	Depth int
+	Event XMLEventType
+
+	stack     []elementScope
+	BaseStack urlStack
+
+	pendingURIs map[string]string
+	tagOpen     bool
+}
+
+// NewXMLPullSerializer returns a new [XMLPullSerializer] writing to w.
+func NewXMLPullSerializer(w io.Writer) *XMLPullSerializer {
+	s := &XMLPullSerializer{}
+	s.Reset(w)
+	return s
+}
+
+// Reset reconfigures the serializer to write to w, discarding all document
+// state. It allows an *XMLPullSerializer to be pooled and reused.
+func (s *XMLPullSerializer) Reset(w io.Writer) {
+	s.w = w
+	s.Depth = 0
+	s.Event = StartDocument
+	s.stack = s.stack[:0]
+	s.BaseStack = s.BaseStack[:0]
+	s.pendingURIs = nil
+	s.tagOpen = false
+}
+
+// SetPrefix binds prefix to nsURI for the next StartTag and its
+// descendants, emitting the corresponding xmlns declaration on that tag.
+// Passing an empty prefix binds nsURI as the default namespace.
+func (s *XMLPullSerializer) SetPrefix(prefix, nsURI string) {
+	if s.pendingURIs == nil {
+		s.pendingURIs = make(map[string]string)
+	}
+	s.pendingURIs[nsURI] = prefix
+}
+
+func (s *XMLPullSerializer) prefixFor(nsURI string) string {
+	if nsURI == "" {
+		return ""
+	}
+	for i := len(s.stack) - 1; i >= 0; i-- {
+		if prefix, ok := s.stack[i].uris[nsURI]; ok {
+			return prefix
+		}
+	}
+	return ""
+}
+
+func (s *XMLPullSerializer) qname(space, name string) string {
+	if prefix := s.prefixFor(space); prefix != "" {
+		return prefix + ":" + name
+	}
+	return name
+}
+
+// namedPrefixFor returns a non-empty prefix already bound to nsURI, or ""
+// if nsURI has only ever been bound as the default (unprefixed) namespace,
+// or not bound at all. Unlike prefixFor, it never returns the empty
+// prefix: per the XML namespaces spec an unprefixed attribute has no
+// namespace, so an attribute can never use the element's default
+// namespace the way an unprefixed element does.
+func (s *XMLPullSerializer) namedPrefixFor(nsURI string) string {
+	if nsURI == xmlNSURI {
+		return xmlPrefix
+	}
+	for i := len(s.stack) - 1; i >= 0; i-- {
+		if prefix, ok := s.stack[i].uris[nsURI]; ok && prefix != "" {
+			return prefix
+		}
+		if prefix, ok := s.stack[i].attrNS[nsURI]; ok {
+			return prefix
+		}
+	}
+	return ""
+}
+
+// attrQName resolves the qualified name to write for an attribute in
+// nsURI, synthesizing and declaring a fresh prefix on the currently open
+// tag if nsURI has no named prefix bound yet.
+func (s *XMLPullSerializer) attrQName(nsURI, name string) (string, error) {
+	if nsURI == "" {
+		return name, nil
+	}
+	prefix := s.namedPrefixFor(nsURI)
+	if prefix == "" {
+		var err error
+		prefix, err = s.declareAttrPrefix(nsURI)
+		if err != nil {
+			return "", err
+		}
+	}
+	return prefix + ":" + name, nil
+}
+
+// declareAttrPrefix synthesizes a prefix not already bound anywhere on the
+// stack, records it as nsURI's named prefix on the innermost (currently
+// open) element, and writes the xmlns declaration for it.
+func (s *XMLPullSerializer) declareAttrPrefix(nsURI string) (string, error) {
+	var prefix string
+	for i := 1; ; i++ {
+		candidate := fmt.Sprintf("ns%d", i)
+		if !s.prefixBound(candidate) {
+			prefix = candidate
+			break
+		}
+	}
+
+	top := &s.stack[len(s.stack)-1]
+	if top.attrNS == nil {
+		top.attrNS = make(map[string]string)
+	}
+	top.attrNS[nsURI] = prefix
+
+	if err := s.writeAttr(xmlnsPrefix+":"+prefix, nsURI); err != nil {
+		return "", err
+	}
+	return prefix, nil
+}
+
+// prefixBound reports whether prefix is already bound to some namespace
+// anywhere on the stack, so declareAttrPrefix doesn't shadow it.
+func (s *XMLPullSerializer) prefixBound(prefix string) bool {
+	for i := len(s.stack) - 1; i >= 0; i-- {
+		for _, p := range s.stack[i].uris {
+			if p == prefix {
+				return true
+			}
+		}
+		for _, p := range s.stack[i].attrNS {
+			if p == prefix {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// StartDocument writes the XML declaration. encoding may be left empty to
+// default to "UTF-8".
+func (s *XMLPullSerializer) StartDocument(encoding string) error {
+	if encoding == "" {
+		encoding = "UTF-8"
+	}
+	s.Event = StartDocument
+	_, err := fmt.Fprintf(s.w, `<?xml version="1.0" encoding="%s"?>`, encoding)
+	return err
+}
+
+// StartTag writes the opening of an element in the given namespace, using
+// a prefix previously bound with SetPrefix (or no prefix if none was
+// bound). Attributes for the tag must be written immediately afterwards
+// via Attribute, before the next call to StartTag, Text, EndTag, Comment,
+// ProcessingInstruction or EndDocument.
+func (s *XMLPullSerializer) StartTag(space, name string) error {
+	if err := s.flushStart(); err != nil {
+		return err
+	}
+
+	// Push the scope before computing the tag's own qname: a prefix bound
+	// via SetPrefix for this element must already be visible to qname so
+	// the opening and closing tags agree on it.
+	scope := elementScope{name: xml.Name{Space: space, Local: name}, uris: s.pendingURIs}
+	s.pendingURIs = nil
+	s.stack = append(s.stack, scope)
+
+	if _, err := fmt.Fprintf(s.w, "<%s", s.qname(space, name)); err != nil {
+		return err
+	}
+	for uri, prefix := range scope.uris {
+		attrName := xmlnsPrefix
+		if prefix != "" {
+			attrName = xmlnsPrefix + ":" + prefix
+		}
+		if err := s.writeAttr(attrName, uri); err != nil {
+			return err
+		}
+	}
+
+	s.Depth++
+	s.Event = StartTag
+	s.tagOpen = true
+	return nil
+}
+
+// Attribute writes an attribute on the element most recently opened with
+// StartTag. It must be called before the tag is closed by any other
+// serializer method.
+//
+// A namespaced attribute always gets a real, non-default prefix: per the
+// XML namespaces spec an unprefixed attribute has no namespace, so it
+// cannot reuse a prefix bound via SetPrefix as the element's default
+// namespace. If space has no named prefix bound yet, Attribute synthesizes
+// one and declares it on the open tag.
+func (s *XMLPullSerializer) Attribute(space, name, value string) error {
+	if !s.tagOpen {
+		return errors.New("goxpp: attribute must follow a starttag")
+	}
+
+	qname, err := s.attrQName(space, name)
+	if err != nil {
+		return err
+	}
+	if err := s.writeAttr(qname, value); err != nil {
+		return err
+	}
+
+	if space == xmlNSURI && name == "base" {
+		if err := s.pushBase(value); err != nil {
+			return err
+		}
+		s.stack[len(s.stack)-1].hasBase = true
+	}
+	return nil
+}
+
+func (s *XMLPullSerializer) writeAttr(qname, value string) error {
+	if _, err := fmt.Fprintf(s.w, ` %s="`, qname); err != nil {
+		return err
+	}
+	if err := xml.EscapeText(s.w, []byte(value)); err != nil {
+		return err
+	}
+	_, err := io.WriteString(s.w, `"`)
+	return err
+}
+
+// Text writes character data, escaping it as needed.
+func (s *XMLPullSerializer) Text(text string) error {
+	if err := s.flushStart(); err != nil {
+		return err
+	}
+	s.Event = Text
+	return xml.EscapeText(s.w, []byte(text))
+}
+
+// EndTag closes the element most recently opened with StartTag.
+func (s *XMLPullSerializer) EndTag() error {
+	if len(s.stack) == 0 {
+		return errors.New("goxpp: endtag without matching starttag")
+	}
+	top := s.stack[len(s.stack)-1]
+
+	if s.tagOpen {
+		s.tagOpen = false
+		if _, err := io.WriteString(s.w, "/>"); err != nil {
+			return err
+		}
+	} else if _, err := fmt.Fprintf(s.w, "</%s>", s.qname(top.name.Space, top.name.Local)); err != nil {
+		return err
+	}
+
+	s.stack = s.stack[:len(s.stack)-1]
+	if top.hasBase {
+		s.BaseStack.pop()
+	}
+	s.Depth--
+	s.Event = EndTag
+	return nil
+}
+
+// Comment writes an XML comment. text is written verbatim and must not
+// itself contain "--".
+func (s *XMLPullSerializer) Comment(text string) error {
+	if err := s.flushStart(); err != nil {
+		return err
+	}
+	s.Event = Comment
+	_, err := fmt.Fprintf(s.w, "<!--%s-->", text)
+	return err
+}
+
+// ProcessingInstruction writes a processing instruction.
+func (s *XMLPullSerializer) ProcessingInstruction(target, inst string) error {
+	if err := s.flushStart(); err != nil {
+		return err
+	}
+	s.Event = ProcessingInstruction
+	_, err := fmt.Fprintf(s.w, "<?%s %s?>", target, inst)
+	return err
+}
+
+// EndDocument closes any still-open elements and marks the document done.
+func (s *XMLPullSerializer) EndDocument() error {
+	if err := s.flushStart(); err != nil {
+		return err
+	}
+	for len(s.stack) > 0 {
+		if err := s.EndTag(); err != nil {
+			return err
+		}
+	}
+	s.Event = EndDocument
+	return nil
+}
+
+// flushStart closes the opening "<tag ...attrs" of a still-open start tag
+// so a sibling token can be written.
+func (s *XMLPullSerializer) flushStart() error {
+	if !s.tagOpen {
+		return nil
+	}
+	s.tagOpen = false
+	_, err := io.WriteString(s.w, ">")
+	return err
+}
+
+// pushBase resolves base against the current top of BaseStack and pushes
+// the result, mirroring [XMLPullParser.pushBase].
+func (s *XMLPullSerializer) pushBase(base string) error {
+	newURL, err := url.Parse(base)
+	if err != nil {
+		return fmt.Errorf("goxpp: %w", err)
+	}
+	if top := s.BaseStack.Top(); top != nil {
+		newURL = top.ResolveReference(newURL)
+	}
+	s.BaseStack.push(newURL)
+	return nil
+}
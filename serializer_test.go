@@ -0,0 +1,95 @@
+package xpp
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestXMLPullSerializer_RoundTrip(t *testing.T) {
+	var sb strings.Builder
+	s := NewXMLPullSerializer(&sb)
+
+	require.NoError(t, s.StartDocument(""))
+	require.NoError(t, s.StartTag("", "root"))
+	require.NoError(t, s.Attribute("", "id", "1"))
+	require.NoError(t, s.StartTag("", "child"))
+	require.NoError(t, s.Text("hi & bye"))
+	require.NoError(t, s.EndTag())
+	require.NoError(t, s.EndTag())
+	require.NoError(t, s.EndDocument())
+
+	assert.Equal(t,
+		`<?xml version="1.0" encoding="UTF-8"?>`+
+			`<root id="1"><child>hi &amp; bye</child></root>`,
+		sb.String())
+}
+
+func TestXMLPullSerializer_PrefixedRoot(t *testing.T) {
+	const atomNS = "http://www.w3.org/2005/Atom"
+
+	var sb strings.Builder
+	s := NewXMLPullSerializer(&sb)
+
+	s.SetPrefix("atom", atomNS)
+	require.NoError(t, s.StartTag(atomNS, "feed"))
+	require.NoError(t, s.StartTag(atomNS, "title"))
+	require.NoError(t, s.Text("hello"))
+	require.NoError(t, s.EndTag())
+	require.NoError(t, s.EndDocument())
+
+	got := sb.String()
+	assert.Equal(t,
+		`<atom:feed xmlns:atom="http://www.w3.org/2005/Atom">`+
+			`<atom:title>hello</atom:title></atom:feed>`,
+		got)
+}
+
+// TestXMLPullSerializer_AttributeDefaultNamespace guards against an
+// attribute in the element's default (unprefixed) namespace silently
+// losing that namespace: per the XML namespaces spec an unprefixed
+// attribute has no namespace, so it must not inherit the default one.
+func TestXMLPullSerializer_AttributeDefaultNamespace(t *testing.T) {
+	const ns = "urn:example"
+
+	var sb strings.Builder
+	s := NewXMLPullSerializer(&sb)
+
+	s.SetPrefix("", ns)
+	require.NoError(t, s.StartTag(ns, "root"))
+	require.NoError(t, s.Attribute(ns, "attr", "val"))
+	require.NoError(t, s.EndTag())
+
+	got := sb.String()
+	assert.NotEqual(t, `<root xmlns="urn:example" attr="val"/>`, got)
+
+	p := NewXMLPullParser(strings.NewReader(got), false, nil)
+	_, err := p.NextTag()
+	require.NoError(t, err)
+	assert.Equal(t, "val", p.AttributeNS(ns, "attr"))
+}
+
+func TestXMLPullSerializer_EmptyElement(t *testing.T) {
+	var sb strings.Builder
+	s := NewXMLPullSerializer(&sb)
+
+	require.NoError(t, s.StartTag("", "br"))
+	require.NoError(t, s.EndTag())
+
+	assert.Equal(t, "<br/>", sb.String())
+}
+
+func TestXMLPullSerializer_Reset(t *testing.T) {
+	var first strings.Builder
+	s := NewXMLPullSerializer(&first)
+	require.NoError(t, s.StartTag("", "a"))
+
+	var second strings.Builder
+	s.Reset(&second)
+	require.NoError(t, s.StartTag("", "b"))
+	require.NoError(t, s.EndTag())
+
+	assert.Equal(t, "<b/>", second.String())
+}
@@ -0,0 +1,65 @@
+package xpp
+
+import (
+	"encoding/xml"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestXMLPullParser_ForEachChild(t *testing.T) {
+	const doc = `<root><a>1</a><b>2</b><a>3</a></root>`
+
+	p := NewXMLPullParser(strings.NewReader(doc), false, nil)
+	_, err := p.NextTag()
+	require.NoError(t, err)
+
+	var texts []string
+	err = p.ForEachChild("", "a", func() error {
+		text, err := p.NextText()
+		if err != nil {
+			return err
+		}
+		texts = append(texts, text)
+		return nil
+	})
+	require.NoError(t, err)
+
+	assert.Equal(t, []string{"1", "3"}, texts)
+	assert.Equal(t, EndTag, p.Event)
+	assert.Equal(t, "root", p.Name)
+}
+
+func TestXMLPullParser_ForEachChild_NotOnStartTag(t *testing.T) {
+	p := NewXMLPullParser(strings.NewReader(`<root/>`), false, nil)
+	err := p.ForEachChild("", "a", func() error { return nil })
+	assert.Error(t, err)
+}
+
+func TestXMLPullParser_SkipUntil(t *testing.T) {
+	const doc = `<root><a/><b/><c/></root>`
+
+	p := NewXMLPullParser(strings.NewReader(doc), false, nil)
+	_, err := p.NextTag()
+	require.NoError(t, err)
+
+	err = p.SkipUntil(func(_, name string, _ []xml.Attr) bool { return name == "b" })
+	require.NoError(t, err)
+	assert.Equal(t, StartTag, p.Event)
+	assert.Equal(t, "b", p.Name)
+}
+
+func TestXMLPullParser_SkipUntil_NoMatch(t *testing.T) {
+	const doc = `<root><a/><b/></root>`
+
+	p := NewXMLPullParser(strings.NewReader(doc), false, nil)
+	_, err := p.NextTag()
+	require.NoError(t, err)
+
+	err = p.SkipUntil(func(_, name string, _ []xml.Attr) bool { return name == "z" })
+	require.NoError(t, err)
+	assert.Equal(t, EndTag, p.Event)
+	assert.Equal(t, "root", p.Name)
+}
@@ -25,7 +25,7 @@ const (
 	ProcessingInstruction
 	Directive
 	IgnorableWhitespace // TODO: ?
-	// TODO: CDSECT ?
+	CDSECT
 )
 
 type (
@@ -69,8 +69,13 @@ type XMLPullParser struct {
 	Name  string
 	Space string
 
-	decoder *xml.Decoder
-	token   any
+	decoder       *xml.Decoder
+	token         any
+	preserveCDATA bool
+	isCDATA       bool
+	cdataSrc      *cdataReader
+	entities      map[string]string
+	lineCounter   *lineCounter
 }
 
 func NewXMLPullParser(r io.Reader, strict bool, cr CharsetReader,
@@ -87,10 +92,22 @@ func NewXMLPullParser(r io.Reader, strict bool, cr CharsetReader,
 	p.Spaces = p.SpacesStack[0]
 
 	if p.decoder == nil {
+		p.lineCounter = newLineCounter(r)
+		r = p.lineCounter
+		if p.preserveCDATA {
+			p.cdataSrc = newCDATAReader(r)
+			r = p.cdataSrc
+		}
 		p.decoder = xml.NewDecoder(r)
 	}
 	p.decoder.Strict = strict
 	p.decoder.CharsetReader = cr
+	if len(p.entities) > 0 {
+		if p.decoder.Entity == nil {
+			p.decoder.Entity = make(map[string]string, len(p.entities))
+		}
+		maps.Copy(p.decoder.Entity, p.entities)
+	}
 	return p
 }
 
@@ -108,7 +125,7 @@ func (p *XMLPullParser) NextTag() (event XMLEventType, err error) {
 	}
 
 	if t != StartTag && t != EndTag {
-		return event, fmt.Errorf("expected starttag or endtag but got %s at offset: %d", p.EventName(t), p.decoder.InputOffset())
+		return event, p.wrapErr(fmt.Errorf("expected starttag or endtag but got %s", p.EventName(t)))
 	}
 
 	return t, nil
@@ -126,7 +143,8 @@ func (p *XMLPullParser) Next() (event XMLEventType, err error) {
 		if event == StartTag ||
 			event == EndTag ||
 			event == EndDocument ||
-			event == Text {
+			event == Text ||
+			event == CDSECT {
 			return event, nil
 		}
 
@@ -154,18 +172,22 @@ func (p *XMLPullParser) NextToken() (XMLEventType, error) {
 			p.Event = EndDocument
 			return p.Event, nil
 		}
-		return 0, fmt.Errorf("goxpp: %w", err)
+		return 0, p.wrapErr(err)
 	}
 
 	p.token = token
 	p.processToken(p.token)
 	p.Event = p.EventType(p.token)
+	if p.Event == Text && p.preserveCDATA && p.cdataSrc != nil && p.cdataSrc.next() {
+		p.Event = CDSECT
+		p.isCDATA = true
+	}
 	return p.Event, nil
 }
 
 func (p *XMLPullParser) NextText() (string, error) {
 	if p.Event != StartTag {
-		return "", errors.New("parser must be on starttag to get nexttext()")
+		return "", p.wrapErr(errors.New("parser must be on starttag to get nexttext()"))
 	}
 
 	t, err := p.Next()
@@ -173,22 +195,22 @@ func (p *XMLPullParser) NextText() (string, error) {
 		return "", err
 	}
 
-	if t != EndTag && t != Text {
-		return "", errors.New("parser must be on endtag or text to read text")
+	if t != EndTag && t != Text && t != CDSECT {
+		return "", p.wrapErr(errors.New("parser must be on endtag or text to read text"))
 	}
 
 	var result strings.Builder
-	for t == Text {
+	for t == Text || t == CDSECT {
 		result.WriteString(p.Text())
 		t, err = p.Next()
 		if err != nil {
 			return "", err
 		}
 
-		if t != EndTag && t != Text {
-			return "", errors.New(
+		if t != EndTag && t != Text && t != CDSECT {
+			return "", p.wrapErr(errors.New(
 				"event text must be immediately followed by endtag or text but got " +
-					p.EventName(t))
+					p.EventName(t)))
 		}
 	}
 	return result.String(), nil
@@ -210,13 +232,61 @@ func (p *XMLPullParser) Text() string {
 }
 
 func (p *XMLPullParser) Skip() error {
+	return p.SkipUntil(func(string, string, []xml.Attr) bool { return false })
+}
+
+// SkipUntil behaves like Skip, but stops as soon as pred returns true for
+// a direct child, leaving the parser positioned on that child's StartTag.
+// If no child matches, it consumes up to and including the current
+// element's matching EndTag, just like Skip.
+func (p *XMLPullParser) SkipUntil(pred func(space, name string, attrs []xml.Attr) bool) error {
+	for {
+		tok, err := p.NextToken()
+		if err != nil {
+			return err
+		}
+		switch tok {
+		case StartTag:
+			if pred(p.Space, p.Name, p.Attrs) {
+				return nil
+			}
+			if err := p.Skip(); err != nil {
+				return err
+			}
+		case EndTag:
+			return nil
+		}
+	}
+}
+
+// ForEachChild iterates the direct children of the current element that
+// match space and name ("*" matches any). The parser must currently be
+// positioned on that element's StartTag. For each matching child it
+// positions the parser on the child's StartTag and invokes fn, which must
+// leave the parser positioned on that child's EndTag before returning
+// (e.g. by calling DecodeElement, NextText, or Skip). Non-matching
+// siblings are skipped automatically. On return the parser is positioned
+// on the parent's EndTag.
+func (p *XMLPullParser) ForEachChild(space, name string, fn func() error) error {
+	if p.Event != StartTag {
+		return p.wrapErr(errors.New("foreachchild can only be called from a starttag event"))
+	}
+
 	for {
 		tok, err := p.NextToken()
 		if err != nil {
 			return err
 		}
+
 		switch tok {
 		case StartTag:
+			if (space == "*" || strings.EqualFold(p.Space, space)) &&
+				(name == "*" || strings.EqualFold(p.Name, name)) {
+				if err := fn(); err != nil {
+					return err
+				}
+				continue
+			}
 			if err := p.Skip(); err != nil {
 				return err
 			}
@@ -235,6 +305,18 @@ func (p *XMLPullParser) Attribute(name string) string {
 	return ""
 }
 
+// AttributeNS returns the value of the attribute identified by its
+// canonical namespace URI and local name, or "" if no such attribute is
+// present on the current tag.
+func (p *XMLPullParser) AttributeNS(nsURI, local string) string {
+	for _, attr := range p.Attrs {
+		if attr.Name.Local == local && attr.Name.Space == nsURI {
+			return attr.Value
+		}
+	}
+	return ""
+}
+
 func (p *XMLPullParser) Expect(event XMLEventType, name string) (err error) {
 	return p.ExpectAll(event, "*", name)
 }
@@ -244,21 +326,34 @@ func (p *XMLPullParser) ExpectAll(event XMLEventType, space, name string) error
 		(space == "*" || strings.EqualFold(p.Space, space)) &&
 		(name == "*" || strings.EqualFold(p.Name, name))
 	if !ok {
-		return fmt.Errorf("expected space:%s name:%s event:%s but got space:%s name:%s event:%s at offset: %d", space, name, p.EventName(event), p.Space, p.Name, p.EventName(p.Event), p.decoder.InputOffset())
+		return p.wrapErr(fmt.Errorf("expected space:%s name:%s event:%s but got space:%s name:%s event:%s", space, name, p.EventName(event), p.Space, p.Name, p.EventName(p.Event)))
 	}
 	return nil
 }
 
+// XMLName returns the full [xml.Name] of the current StartTag or EndTag
+// token, including both its local name and its canonical namespace URI as
+// resolved by [xml.Decoder].
+func (p *XMLPullParser) XMLName() xml.Name {
+	switch tt := p.token.(type) {
+	case xml.StartElement:
+		return tt.Name
+	case xml.EndElement:
+		return tt.Name
+	}
+	return xml.Name{}
+}
+
 func (p *XMLPullParser) DecodeElement(v any) error {
 	if p.Event != StartTag {
-		return errors.New("decodeelement can only be called from a starttag event")
+		return p.wrapErr(errors.New("decodeelement can only be called from a starttag event"))
 	}
 	startToken := p.token.(xml.StartElement)
 
 	// Consumes all tokens until the matching end token.
 	err := p.decoder.DecodeElement(v, &startToken)
 	if err != nil {
-		return fmt.Errorf("goxpp: %w", err)
+		return p.wrapErr(err)
 	}
 	name := p.Name
 
@@ -307,6 +402,8 @@ func (p *XMLPullParser) EventName(e XMLEventType) string {
 		return "Text"
 	case IgnorableWhitespace:
 		return "IgnorableWhitespace"
+	case CDSECT:
+		return "CDSECT"
 	}
 	return ""
 }
@@ -338,7 +435,7 @@ func (p *XMLPullParser) XmlBaseResolveUrl(u string) (*url.URL, error) {
 
 	relURL, err := url.Parse(u)
 	if err != nil {
-		return nil, fmt.Errorf("goxpp: %w", err)
+		return nil, p.wrapErr(err)
 	}
 	if curr.Path != "" && u != "" && curr.Path[len(curr.Path)-1] != '/' {
 		// There's no reason someone would use a path in xml:base if they
@@ -379,8 +476,15 @@ func (p *XMLPullParser) resetTokenState() {
 	p.Attrs = nil
 	p.Name = ""
 	p.Space = ""
+	p.isCDATA = false
 }
 
+// IsCDATA reports whether the current Text/CDSECT token originated from a
+// <![CDATA[ ... ]]> section in the source document. It is only meaningful
+// when the parser was constructed with [WithPreserveCDATA]; otherwise it
+// always returns false.
+func (p *XMLPullParser) IsCDATA() bool { return p.isCDATA }
+
 func (p *XMLPullParser) trackNamespaces(t xml.StartElement) {
 	newSpace := make(map[string]string, len(p.Spaces))
 	maps.Copy(newSpace, p.Spaces)
@@ -417,7 +521,7 @@ func (p *XMLPullParser) pushBase() error {
 
 	newURL, err := url.Parse(base)
 	if err != nil {
-		return fmt.Errorf("goxpp: %w", err)
+		return p.wrapErr(err)
 	}
 
 	topURL := p.BaseStack.Top()